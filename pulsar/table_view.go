@@ -0,0 +1,159 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import (
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/apache/pulsar-client-go/pulsar/log"
+)
+
+// TableView provides a key-value map view of a compacted topic. TableView is built on top
+// of the Reader API, and it keeps polling the compacted topic until reaching the latest message,
+// constructing a map backed by the content of the topic, with the latest value for each key.
+type TableView interface {
+	// Size returns the number of key/value entries currently held by the TableView.
+	Size() int
+
+	// IsEmpty returns true if the TableView has no entries.
+	IsEmpty() bool
+
+	// ContainsKey returns true if the TableView holds an entry for the given key.
+	ContainsKey(key string) bool
+
+	// Get returns the value associated with the given key, or nil if the key is not present.
+	Get(key string) interface{}
+
+	// Entries returns a copy of the whole TableView content as a map.
+	Entries() map[string]interface{}
+
+	// Keys returns the keys currently held by the TableView.
+	Keys() []string
+
+	// GetRange returns the entries with from <= key, and key < to when to is non-empty. The
+	// result is a map, so it carries no key order.
+	GetRange(from, to string) map[string]interface{}
+
+	// GetByPrefix returns the entries whose key starts with prefix.
+	GetByPrefix(prefix string) map[string]interface{}
+
+	// AddIndex registers a secondary index named name, maintained by calling extractor with
+	// each key/value pair as entries are added or updated; a tombstoned entry has its terms
+	// removed from the index without extractor being called. The returned Index can be used to
+	// look up primary keys by an extracted term in place of an O(N) scan.
+	AddIndex(name string, extractor func(key string, value interface{}) []string) Index
+
+	// BootstrapProgress reports how many of the known partitions have completed their initial
+	// backlog drain (loaded) out of the total number of partitions seen so far (total). loaded
+	// grows as each partition finishes, so it can be polled while a partitionUpdateCheck is
+	// still draining newly added partitions. The TableView is only returned once the initial
+	// bootstrap inside newTableView has finished, so progress of that first drain is visible
+	// only through TableViewOptions.OnPartitionReady.
+	BootstrapProgress() (loaded, total int)
+
+	// Filter returns a derived TableView holding only the entries of this TableView for which
+	// predicate returns true, kept up to date as this TableView changes. Listeners registered
+	// on the derived view only fire for updates that pass predicate.
+	Filter(predicate func(key string, value interface{}) bool) TableView
+
+	// Window returns a derived TableView retaining only the entries of this TableView that
+	// have been updated within the last duration, evicting older entries on a background
+	// timer. This lets applications build lightweight materializations such as "active
+	// sessions in the last 5 minutes" without maintaining parallel state. Entries already
+	// present in this TableView when Window is called are treated as updated at that moment;
+	// their earlier update times are not taken into account.
+	Window(duration time.Duration) TableView
+
+	// ForEach iterates over the entries currently held by the TableView.
+	ForEach(action func(string, interface{}) error) error
+
+	// ForEachAndListen iterates over the entries currently held by the TableView and registers
+	// action to be called for every new entry that is added or updated afterwards, and with a
+	// nil value for every entry that is tombstoned afterwards.
+	ForEachAndListen(action func(string, interface{}) error) error
+
+	// Delete publishes an empty-payload message for key, marking it as deleted in the compacted
+	// topic. Once the tombstone has been applied locally, the entry is removed from the
+	// TableView (subject to TableViewOptions.TombstoneRetention).
+	Delete(key string) error
+
+	// Close closes the TableView and stops the background readers.
+	Close()
+}
+
+// TableViewOptions specifies the options for creating a TableView.
+type TableViewOptions struct {
+	// Topic specifies the topic this TableView will be backed by. This is required.
+	Topic string
+
+	// SchemaValueType specifies the Go type that values are deserialized into. This is
+	// required when Schema is set.
+	//
+	// Deprecated: this reflect-based dispatch forces a cast at every TableView call site and
+	// cannot be checked at compile time. Prefer CreateTypedTableView, which infers the value
+	// type from its type parameter instead.
+	SchemaValueType reflect.Type
+
+	// Schema specifies the schema used to decode the messages. Defaults to the bytes schema.
+	Schema Schema
+
+	// AutoUpdatePartitionsInterval specifies the interval to check for changes in the number
+	// of partitions of the backing topic. Defaults to 1 minute.
+	AutoUpdatePartitionsInterval time.Duration
+
+	// TombstoneRetention specifies how long a key is retained in the TableView after it has
+	// been tombstoned (i.e. deleted via an empty-payload message) before it is evicted to
+	// reclaim memory. Live keys that simply stop receiving updates are never evicted. A zero
+	// value (the default) disables eviction and keeps tombstoned keys around indefinitely.
+	TombstoneRetention time.Duration
+
+	// SnapshotStore, when set, is used to load a previously persisted snapshot of the
+	// TableView content on startup (seeding tv.data and starting each partition's reader from
+	// its own MessageID in the snapshot instead of EarliestMessageID()), and to periodically
+	// persist new snapshots afterwards. This significantly reduces cold-start latency for large
+	// compacted topics.
+	SnapshotStore SnapshotStore
+
+	// SnapshotInterval specifies how often a new snapshot is persisted via SnapshotStore.
+	// Only used when SnapshotStore is set. Defaults to 5 minutes.
+	SnapshotInterval time.Duration
+
+	// BootstrapConcurrency specifies how many partitions are drained concurrently when
+	// populating the TableView, either on creation or when new partitions appear. Defaults to 4.
+	BootstrapConcurrency int
+
+	// OnPartitionReady, when set, is invoked once a partition has finished its initial backlog
+	// drain, with the number of entries read from that partition. It is called from one of the
+	// bootstrap worker goroutines, so it must not block for long.
+	OnPartitionReady func(partition string, entries int)
+
+	// TracerProvider specifies the OpenTelemetry TracerProvider used to instrument the
+	// TableView. Defaults to otel.GetTracerProvider().
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider specifies the OpenTelemetry MeterProvider used to instrument the
+	// TableView. Defaults to otel.GetMeterProvider().
+	MeterProvider metric.MeterProvider
+
+	// Logger specifies the logger used by the TableView. Defaults to logrus.StandardLogger().
+	Logger log.Logger
+}
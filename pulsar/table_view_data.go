@@ -0,0 +1,137 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import (
+	"sort"
+	"strings"
+)
+
+// orderedData is the sorted key/value store backing a TableViewImpl. Keeping keys in sorted
+// order lets GetRange and GetByPrefix run in O(log n + k) instead of scanning every entry.
+// Sorting is lazy: Set appends a previously-unseen key in O(1) amortized instead of paying an
+// O(n) slice shift to insert it in position, and the slice is only actually sorted again, in
+// one O(n log n) pass, the next time an ordering-dependent method is called. This keeps a
+// bootstrap's burst of inserts (chunk0-2/chunk0-4) cheap; it's only callers that interleave
+// inserts with range/prefix/keys queries that pay sorting more than once.
+type orderedData struct {
+	values map[string]interface{}
+
+	// keys holds every key seen, appended in the order Set first observed them. It is only
+	// guaranteed to be sorted when sorted is true; ensureSorted brings it up to date on demand.
+	keys   []string
+	sorted bool
+}
+
+func newOrderedData() *orderedData {
+	return &orderedData{values: make(map[string]interface{}), sorted: true}
+}
+
+func (o *orderedData) Len() int {
+	return len(o.values)
+}
+
+func (o *orderedData) Get(key string) (interface{}, bool) {
+	v, ok := o.values[key]
+	return v, ok
+}
+
+// Set is O(1) amortized for a previously-unseen key: it's appended to keys rather than
+// inserted in sorted position, deferring that cost to the next call that needs ordering.
+func (o *orderedData) Set(key string, value interface{}) {
+	if _, exists := o.values[key]; !exists {
+		if o.sorted && len(o.keys) > 0 && key < o.keys[len(o.keys)-1] {
+			o.sorted = false
+		}
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *orderedData) Delete(key string) {
+	if _, exists := o.values[key]; !exists {
+		return
+	}
+	delete(o.values, key)
+	o.ensureSorted()
+	i := sort.SearchStrings(o.keys, key)
+	o.keys = append(o.keys[:i], o.keys[i+1:]...)
+}
+
+// ensureSorted brings keys back into sorted order if a Set call has appended a key out of
+// order since the last time it was needed. It is a no-op (O(1)) when nothing has changed.
+func (o *orderedData) ensureSorted() {
+	if o.sorted {
+		return
+	}
+	sort.Strings(o.keys)
+	o.sorted = true
+}
+
+// Keys returns a copy of all keys in sorted order, including tombstoned (nil-valued) keys.
+func (o *orderedData) Keys() []string {
+	o.ensureSorted()
+	keys := make([]string, len(o.keys))
+	copy(keys, o.keys)
+	return keys
+}
+
+// ForEach calls action for every key in sorted order, including tombstoned (nil-valued) keys,
+// stopping early if action returns false.
+func (o *orderedData) ForEach(action func(key string, value interface{}) bool) {
+	o.ensureSorted()
+	for _, k := range o.keys {
+		if !action(k, o.values[k]) {
+			return
+		}
+	}
+}
+
+// Range returns the non-tombstoned entries with from <= key, and key < to when to is
+// non-empty.
+func (o *orderedData) Range(from, to string) map[string]interface{} {
+	o.ensureSorted()
+	start := sort.SearchStrings(o.keys, from)
+	end := len(o.keys)
+	if to != "" {
+		end = sort.SearchStrings(o.keys, to)
+	}
+	result := make(map[string]interface{})
+	for _, k := range o.keys[start:end] {
+		if v := o.values[k]; v != nil {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Prefix returns the non-tombstoned entries whose key starts with prefix.
+func (o *orderedData) Prefix(prefix string) map[string]interface{} {
+	o.ensureSorted()
+	start := sort.SearchStrings(o.keys, prefix)
+	result := make(map[string]interface{})
+	for _, k := range o.keys[start:] {
+		if !strings.HasPrefix(k, prefix) {
+			break
+		}
+		if v := o.values[k]; v != nil {
+			result[k] = v
+		}
+	}
+	return result
+}
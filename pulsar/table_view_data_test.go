@@ -0,0 +1,102 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedDataKeysAreSortedRegardlessOfInsertionOrder(t *testing.T) {
+	o := newOrderedData()
+	o.Set("c", 3)
+	o.Set("a", 1)
+	o.Set("b", 2)
+
+	want := []string{"a", "b", "c"}
+	if got := o.Keys(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Keys() = %v, want %v", got, want)
+	}
+}
+
+func TestOrderedDataDeleteRemovesKeyAfterOutOfOrderInserts(t *testing.T) {
+	o := newOrderedData()
+	o.Set("c", 3)
+	o.Set("a", 1)
+	o.Set("b", 2)
+
+	o.Delete("a")
+
+	if _, ok := o.Get("a"); ok {
+		t.Fatalf("expected deleted key to be gone")
+	}
+	want := []string{"b", "c"}
+	if got := o.Keys(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Keys() after Delete = %v, want %v", got, want)
+	}
+}
+
+func TestOrderedDataRangeAndPrefix(t *testing.T) {
+	o := newOrderedData()
+	for _, k := range []string{"user:3", "user:1", "user:2", "order:1"} {
+		o.Set(k, k)
+	}
+
+	got := o.Range("user:1", "user:3")
+	want := map[string]interface{}{"user:1": "user:1", "user:2": "user:2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Range() = %v, want %v", got, want)
+	}
+
+	got = o.Prefix("user:")
+	want = map[string]interface{}{"user:1": "user:1", "user:2": "user:2", "user:3": "user:3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Prefix() = %v, want %v", got, want)
+	}
+}
+
+func TestOrderedDataSetIsAppendOnlyUntilAnOrderedQuery(t *testing.T) {
+	o := newOrderedData()
+	o.Set("b", 1)
+	o.Set("a", 2)
+
+	if o.sorted {
+		t.Fatalf("expected an out-of-order insert to leave the data unsorted until queried")
+	}
+	if got := o.keys; !reflect.DeepEqual(got, []string{"b", "a"}) {
+		t.Fatalf("keys before an ordered query = %v, want insertion order [b a]", got)
+	}
+
+	_ = o.Keys()
+	if !o.sorted {
+		t.Fatalf("expected Keys() to bring the data back into sorted order")
+	}
+}
+
+func TestOrderedDataOverwriteDoesNotDuplicateKey(t *testing.T) {
+	o := newOrderedData()
+	o.Set("a", 1)
+	o.Set("a", 2)
+
+	if o.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1", o.Len())
+	}
+	if v, _ := o.Get("a"); v != 2 {
+		t.Fatalf("Get(a) = %v, want 2", v)
+	}
+}
@@ -0,0 +1,383 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import (
+	"sync"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar/log"
+)
+
+// derivedTableView is a TableView kept in sync with a parent TableView via
+// ForEachAndListen, instead of reading directly from a compacted topic. It backs
+// TableView.Filter and TableView.Window, and can itself be filtered/windowed further.
+type derivedTableView struct {
+	parent TableView
+	logger log.Logger
+
+	// predicate is nil for a windowed view, where membership is decided purely by recency.
+	predicate func(key string, value interface{}) bool
+	// window is zero for a filtered view, which has no time-based eviction.
+	window time.Duration
+
+	dataMu      sync.Mutex
+	data        *orderedData
+	lastUpdated map[string]time.Time
+
+	// listeners is keyed by an incrementing id rather than held in a plain slice, for the same
+	// reason as TableViewImpl.listeners: it lets a further-derived view (d.Filter/d.Window)
+	// remove its registration from d on Close.
+	listenersMu    sync.Mutex
+	listeners      map[int]func(string, interface{}) error
+	nextListenerID int
+
+	indexesMu sync.Mutex
+	indexes   map[string]*tableViewIndex
+
+	// detachFromParent removes handleParentUpdate from the parent's listeners, so Close stops
+	// this view from being kept up to date (and kept alive) by the parent once the caller is
+	// done with it. It is nil if registration with the parent failed.
+	detachFromParent func()
+
+	closeOnce sync.Once
+	closedCh  chan struct{}
+}
+
+// removableListener is implemented by both TableViewImpl and derivedTableView, letting a
+// derivedTableView register with either kind of parent while keeping a handle it can use to
+// remove the registration again on Close.
+type removableListener interface {
+	forEachAndListenRemovable(action func(string, interface{}) error) (remove func(), err error)
+}
+
+func newDerivedTableView(
+	parent TableView, predicate func(string, interface{}) bool, window time.Duration, logger log.Logger,
+) TableView {
+	d := &derivedTableView{
+		parent:      parent,
+		logger:      logger,
+		predicate:   predicate,
+		window:      window,
+		data:        newOrderedData(),
+		lastUpdated: make(map[string]time.Time),
+		listeners:   make(map[int]func(string, interface{}) error),
+		indexes:     make(map[string]*tableViewIndex),
+		closedCh:    make(chan struct{}),
+	}
+
+	// forEachAndListenRemovable both seeds d with the parent's current content and keeps d
+	// updated as the parent changes, under a single registration, the same as
+	// ForEachAndListen, but also hands back a function to undo the registration so Close can
+	// detach d from the parent instead of leaking it forever.
+	if rl, ok := parent.(removableListener); ok {
+		remove, err := rl.forEachAndListenRemovable(d.handleParentUpdate)
+		if err != nil {
+			logger.Errorf("failed to seed derived table view: %v", err)
+		} else {
+			d.detachFromParent = remove
+		}
+	} else if err := parent.ForEachAndListen(d.handleParentUpdate); err != nil {
+		logger.Errorf("failed to seed derived table view: %v", err)
+	}
+
+	if window > 0 {
+		go d.evictStaleEntries()
+	}
+
+	return d
+}
+
+// handleParentUpdate is registered as a listener on the parent TableView. value is nil when
+// key was tombstoned upstream.
+func (d *derivedTableView) handleParentUpdate(key string, value interface{}) error {
+	select {
+	case <-d.closedCh:
+		// d has been closed (and should be in the process of detaching from the parent); don't
+		// do any further work even if this call raced with that detach.
+		return nil
+	default:
+	}
+
+	passes := value != nil && (d.predicate == nil || d.predicate(key, value))
+
+	d.dataMu.Lock()
+	if passes {
+		d.data.Set(key, value)
+		if d.window > 0 {
+			d.lastUpdated[key] = time.Now()
+		}
+	} else {
+		d.data.Delete(key)
+		delete(d.lastUpdated, key)
+	}
+	d.dataMu.Unlock()
+
+	d.indexesMu.Lock()
+	for _, idx := range d.indexes {
+		if passes {
+			idx.update(key, value)
+		} else {
+			idx.update(key, nil)
+		}
+	}
+	d.indexesMu.Unlock()
+
+	if passes {
+		d.notifyListeners(key, value)
+	}
+	return nil
+}
+
+func (d *derivedTableView) notifyListeners(key string, value interface{}) {
+	d.listenersMu.Lock()
+	listeners := make([]func(string, interface{}) error, 0, len(d.listeners))
+	for _, listener := range d.listeners {
+		listeners = append(listeners, listener)
+	}
+	d.listenersMu.Unlock()
+
+	for _, listener := range listeners {
+		if err := listener(key, value); err != nil {
+			d.logger.Errorf("derived table view listener failed for key %s: %v", key, err)
+		}
+	}
+}
+
+// evictStaleEntries runs until the derived view is closed, periodically removing entries
+// that have not been updated within window.
+func (d *derivedTableView) evictStaleEntries() {
+	interval := d.window / 10
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.closedCh:
+			return
+		case <-ticker.C:
+			d.sweepStaleEntries()
+		}
+	}
+}
+
+func (d *derivedTableView) sweepStaleEntries() {
+	select {
+	case <-d.closedCh:
+		return
+	default:
+	}
+
+	horizon := time.Now().Add(-d.window)
+
+	d.dataMu.Lock()
+	var evicted []string
+	for key, updatedAt := range d.lastUpdated {
+		if updatedAt.Before(horizon) {
+			evicted = append(evicted, key)
+		}
+	}
+	for _, key := range evicted {
+		d.data.Delete(key)
+		delete(d.lastUpdated, key)
+	}
+	d.dataMu.Unlock()
+
+	for _, key := range evicted {
+		d.indexesMu.Lock()
+		for _, idx := range d.indexes {
+			idx.update(key, nil)
+		}
+		d.indexesMu.Unlock()
+		d.notifyListeners(key, nil)
+	}
+}
+
+func (d *derivedTableView) Size() int {
+	d.dataMu.Lock()
+	defer d.dataMu.Unlock()
+	return d.data.Len()
+}
+
+func (d *derivedTableView) IsEmpty() bool {
+	d.dataMu.Lock()
+	defer d.dataMu.Unlock()
+	return d.data.Len() == 0
+}
+
+func (d *derivedTableView) ContainsKey(key string) bool {
+	d.dataMu.Lock()
+	defer d.dataMu.Unlock()
+	v, ok := d.data.Get(key)
+	return ok && v != nil
+}
+
+func (d *derivedTableView) Get(key string) interface{} {
+	d.dataMu.Lock()
+	defer d.dataMu.Unlock()
+	v, _ := d.data.Get(key)
+	return v
+}
+
+func (d *derivedTableView) Entries() map[string]interface{} {
+	d.dataMu.Lock()
+	defer d.dataMu.Unlock()
+	entries := make(map[string]interface{}, d.data.Len())
+	d.data.ForEach(func(k string, v interface{}) bool {
+		if v != nil {
+			entries[k] = v
+		}
+		return true
+	})
+	return entries
+}
+
+func (d *derivedTableView) Keys() []string {
+	d.dataMu.Lock()
+	defer d.dataMu.Unlock()
+	return d.data.Keys()
+}
+
+func (d *derivedTableView) GetRange(from, to string) map[string]interface{} {
+	d.dataMu.Lock()
+	defer d.dataMu.Unlock()
+	return d.data.Range(from, to)
+}
+
+func (d *derivedTableView) GetByPrefix(prefix string) map[string]interface{} {
+	d.dataMu.Lock()
+	defer d.dataMu.Unlock()
+	return d.data.Prefix(prefix)
+}
+
+func (d *derivedTableView) AddIndex(name string, extractor func(string, interface{}) []string) Index {
+	idx := newTableViewIndex(extractor)
+
+	d.dataMu.Lock()
+	d.data.ForEach(func(k string, v interface{}) bool {
+		idx.update(k, v)
+		return true
+	})
+	d.dataMu.Unlock()
+
+	d.indexesMu.Lock()
+	d.indexes[name] = idx
+	d.indexesMu.Unlock()
+
+	return idx
+}
+
+// BootstrapProgress delegates to the parent, since a derived view has no bootstrap of its own
+// beyond replaying the parent's current content.
+func (d *derivedTableView) BootstrapProgress() (loaded, total int) {
+	return d.parent.BootstrapProgress()
+}
+
+// Filter derives a further-filtered view from this one.
+func (d *derivedTableView) Filter(predicate func(string, interface{}) bool) TableView {
+	return newDerivedTableView(d, predicate, 0, d.logger)
+}
+
+// Window derives a further time-windowed view from this one.
+func (d *derivedTableView) Window(duration time.Duration) TableView {
+	return newDerivedTableView(d, nil, duration, d.logger)
+}
+
+func (d *derivedTableView) ForEach(action func(string, interface{}) error) error {
+	d.dataMu.Lock()
+	defer d.dataMu.Unlock()
+	var actionErr error
+	d.data.ForEach(func(k string, v interface{}) bool {
+		if v == nil {
+			return true
+		}
+		if err := action(k, v); err != nil {
+			actionErr = err
+			return false
+		}
+		return true
+	})
+	return actionErr
+}
+
+func (d *derivedTableView) ForEachAndListen(action func(string, interface{}) error) error {
+	d.listenersMu.Lock()
+	defer d.listenersMu.Unlock()
+
+	if err := d.ForEach(action); err != nil {
+		return err
+	}
+
+	d.addListenerLocked(action)
+	return nil
+}
+
+// forEachAndListenRemovable implements removableListener, letting a further-derived view
+// (d.Filter/d.Window) detach from d again on Close.
+func (d *derivedTableView) forEachAndListenRemovable(
+	action func(string, interface{}) error,
+) (remove func(), err error) {
+	d.listenersMu.Lock()
+	defer d.listenersMu.Unlock()
+
+	if err := d.ForEach(action); err != nil {
+		return nil, err
+	}
+
+	id := d.addListenerLocked(action)
+	return func() { d.removeListener(id) }, nil
+}
+
+// addListenerLocked registers action under the given id and returns it. d.listenersMu must
+// already be held.
+func (d *derivedTableView) addListenerLocked(action func(string, interface{}) error) int {
+	if d.listeners == nil {
+		d.listeners = make(map[int]func(string, interface{}) error)
+	}
+	id := d.nextListenerID
+	d.nextListenerID++
+	d.listeners[id] = action
+	return id
+}
+
+func (d *derivedTableView) removeListener(id int) {
+	d.listenersMu.Lock()
+	defer d.listenersMu.Unlock()
+	delete(d.listeners, id)
+}
+
+// Delete deletes key from the original backing topic via the parent, so that the deletion is
+// observed by this view (and any siblings) once it flows back through the parent.
+func (d *derivedTableView) Delete(key string) error {
+	return d.parent.Delete(key)
+}
+
+// Close stops this derived view's own background eviction timer and detaches it from its
+// parent, so the parent no longer keeps it (and its listeners/indexes) alive once the caller
+// is done with it.
+func (d *derivedTableView) Close() {
+	d.closeOnce.Do(func() {
+		close(d.closedCh)
+		if d.detachFromParent != nil {
+			d.detachFromParent()
+		}
+	})
+}
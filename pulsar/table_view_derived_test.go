@@ -0,0 +1,84 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import "testing"
+
+func TestDerivedTableViewCloseDetachesFromParent(t *testing.T) {
+	tv := newTestTableView(t, 0)
+	tv.data.Set("k1", "v1")
+
+	derived := newDerivedTableView(tv, func(string, interface{}) bool { return true }, 0, tv.logger)
+
+	tv.listenersMu.Lock()
+	n := len(tv.listeners)
+	tv.listenersMu.Unlock()
+	if n != 1 {
+		t.Fatalf("expected parent to have exactly one listener registered, got %d", n)
+	}
+
+	derived.Close()
+
+	tv.listenersMu.Lock()
+	n = len(tv.listeners)
+	tv.listenersMu.Unlock()
+	if n != 0 {
+		t.Fatalf("expected Close() to detach the derived view from its parent, got %d listeners remaining", n)
+	}
+}
+
+func TestDerivedTableViewIgnoresParentUpdatesAfterClose(t *testing.T) {
+	tv := newTestTableView(t, 0)
+
+	view := newDerivedTableView(tv, nil, 0, tv.logger)
+	d := view.(*derivedTableView)
+
+	view.Close()
+
+	if err := d.handleParentUpdate("late", "value"); err != nil {
+		t.Fatalf("handleParentUpdate() error = %v", err)
+	}
+	if view.ContainsKey("late") {
+		t.Fatalf("expected handleParentUpdate to be a no-op once the derived view is closed")
+	}
+}
+
+func TestDerivedTableViewChainDetachesFromDerivedParent(t *testing.T) {
+	tv := newTestTableView(t, 0)
+
+	parent := newDerivedTableView(tv, func(string, interface{}) bool { return true }, 0, tv.logger)
+	parentImpl := parent.(*derivedTableView)
+
+	child := parent.Filter(func(string, interface{}) bool { return true })
+
+	parentImpl.listenersMu.Lock()
+	n := len(parentImpl.listeners)
+	parentImpl.listenersMu.Unlock()
+	if n != 1 {
+		t.Fatalf("expected derived parent to have exactly one listener registered, got %d", n)
+	}
+
+	child.Close()
+
+	parentImpl.listenersMu.Lock()
+	n = len(parentImpl.listeners)
+	parentImpl.listenersMu.Unlock()
+	if n != 0 {
+		t.Fatalf("expected child Close() to detach from its derived parent, got %d listeners remaining", n)
+	}
+}
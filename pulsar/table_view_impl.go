@@ -22,9 +22,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/apache/pulsar-client-go/pulsar/log"
 	"github.com/sirupsen/logrus"
 )
@@ -39,13 +43,46 @@ type TableViewImpl struct {
 	options TableViewOptions
 
 	dataMu sync.Mutex
-	data   map[string]interface{}
+	data   *orderedData
+
+	rawData     map[string][]byte
+	lastUpdated map[string]time.Time
+	// partitionMessageIDs holds, for each partition, the MessageID of the last message handled
+	// from it. A MessageID is only meaningful for the partition (ledger) it came from, so this
+	// is tracked per partition rather than as a single value - reusing one partition's
+	// MessageID to seed another partition's reader would silently skip or replay messages.
+	partitionMessageIDs map[string]MessageID
+
+	// bootstrapPartitionStartIDs, when set, gives the StartMessageID to use for each
+	// partition's reader during the initial partitionUpdateCheck instead of
+	// EarliestMessageID(). It is populated from a loaded snapshot and cleared once the initial
+	// bootstrap completes.
+	bootstrapPartitionStartIDs map[string]MessageID
 
 	readersMu     sync.Mutex
 	cancelReaders map[string]cancelReader
 
-	listenersMu sync.Mutex
-	listeners   []func(string, interface{}) error
+	// bootstrapLoaded and bootstrapTotal back BootstrapProgress(), tracking how many of the
+	// partitions seen so far have completed their initial backlog drain. bootstrapAccounting
+	// tracks which partitions have already been counted into bootstrapTotal, so a partition
+	// that keeps failing to bootstrap is retried on every partitionUpdateCheck without
+	// inflating the total each time.
+	bootstrapLoaded     int32
+	bootstrapTotal      int32
+	bootstrapAccounting *bootstrapAccounting
+
+	// listeners is keyed by an incrementing id rather than held in a plain slice, so a
+	// registration can be removed again (func values aren't comparable, so a slice entry
+	// couldn't be identified for removal). This is what lets a derivedTableView detach itself
+	// from its parent on Close instead of leaking a permanent strong reference.
+	listenersMu    sync.Mutex
+	listeners      map[int]func(string, interface{}) error
+	nextListenerID int
+
+	indexesMu sync.Mutex
+	indexes   map[string]*tableViewIndex
+
+	telemetry *tableViewTelemetry
 
 	logger   log.Logger
 	closed   bool
@@ -72,25 +109,100 @@ func newTableView(client *client, options TableViewOptions) (TableView, error) {
 		options.AutoUpdatePartitionsInterval = time.Minute
 	}
 
+	if options.SnapshotStore != nil && options.SnapshotInterval == 0 {
+		options.SnapshotInterval = 5 * time.Minute
+	}
+
 	tv := TableViewImpl{
-		client:        client,
-		options:       options,
-		data:          make(map[string]interface{}),
-		cancelReaders: make(map[string]cancelReader),
-		logger:        logger,
-		closedCh:      make(chan struct{}),
+		client:              client,
+		options:             options,
+		data:                newOrderedData(),
+		rawData:             make(map[string][]byte),
+		lastUpdated:         make(map[string]time.Time),
+		partitionMessageIDs: make(map[string]MessageID),
+		cancelReaders:       make(map[string]cancelReader),
+		listeners:           make(map[int]func(string, interface{}) error),
+		indexes:             make(map[string]*tableViewIndex),
+		logger:              logger,
+		closedCh:            make(chan struct{}),
+	}
+	tv.bootstrapAccounting = newBootstrapAccounting()
+
+	telemetry, err := newTableViewTelemetry(&tv, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up TableView instrumentation: %w", err)
+	}
+	tv.telemetry = telemetry
+
+	if options.SnapshotStore != nil {
+		snapshot, partitionMessageIDs, err := options.SnapshotStore.Load(options.Topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load snapshot for topic %s: %w", options.Topic, err)
+		}
+		if snapshot != nil {
+			tv.seedFromSnapshot(snapshot)
+			tv.bootstrapPartitionStartIDs = partitionMessageIDs
+		}
 	}
 
 	// Do an initial round of partition update check to make sure we can populate the partition readers
 	if err := tv.partitionUpdateCheck(); err != nil {
 		return nil, err
 	}
+	tv.bootstrapPartitionStartIDs = nil
+
 	go tv.periodicPartitionUpdateCheck()
+	if options.SnapshotStore != nil {
+		go tv.periodicSnapshot()
+	}
 
 	return &tv, nil
 }
 
+// bootstrapResult is the outcome of draining a single partition's backlog, produced by a
+// partitionUpdateCheck worker and consumed back on the calling goroutine to register the
+// reader.
+type bootstrapResult struct {
+	partition string
+	reader    Reader
+	entries   int
+	err       error
+}
+
+// bootstrapAccounting tracks which partitions have already been counted towards
+// bootstrapTotal, so that a partition retried across multiple partitionUpdateCheck calls
+// (because it keeps failing to bootstrap, or is still missing from tv.cancelReaders) is only
+// counted into the total once.
+type bootstrapAccounting struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newBootstrapAccounting() *bootstrapAccounting {
+	return &bootstrapAccounting{seen: make(map[string]bool)}
+}
+
+// countNew marks every partition in partitions as seen and returns how many of them had not
+// been seen before.
+func (b *bootstrapAccounting) countNew(partitions []string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := 0
+	for _, partition := range partitions {
+		if !b.seen[partition] {
+			b.seen[partition] = true
+			n++
+		}
+	}
+	return n
+}
+
 func (tv *TableViewImpl) partitionUpdateCheck() error {
+	ctx, span := tv.telemetry.startSpan(context.Background(), "partition_update_check",
+		attribute.String("pulsar.topic", tv.options.Topic))
+	defer span.End()
+
 	partitionsArray, err := tv.client.TopicPartitions(tv.options.Topic)
 	if err != nil {
 		return fmt.Errorf("tv.client.TopicPartitions(%s) failed: %w", tv.options.Topic, err)
@@ -102,7 +214,6 @@ func (tv *TableViewImpl) partitionUpdateCheck() error {
 	}
 
 	tv.readersMu.Lock()
-	defer tv.readersMu.Unlock()
 
 	for partition, cancelReader := range tv.cancelReaders {
 		if _, ok := partitions[partition]; !ok {
@@ -112,44 +223,168 @@ func (tv *TableViewImpl) partitionUpdateCheck() error {
 		}
 	}
 
+	var newPartitions []string
 	for partition := range partitions {
 		if _, ok := tv.cancelReaders[partition]; !ok {
-			reader, err := newReader(tv.client, ReaderOptions{
-				Topic:          partition,
-				StartMessageID: EarliestMessageID(),
-				ReadCompacted:  true,
-				// TODO: Pooling?
-				Schema: tv.options.Schema,
-			})
-			if err != nil {
-				return fmt.Errorf("create new reader failed for %s: %w", partition, err)
+			newPartitions = append(newPartitions, partition)
+		}
+	}
+
+	tv.readersMu.Unlock()
+
+	if len(newPartitions) == 0 {
+		return nil
+	}
+
+	// startMessageIDFor resolves the StartMessageID for partition's reader: its own entry from
+	// a loaded snapshot if there is one (resuming from that position instead of draining the
+	// whole compacted topic again), or EarliestMessageID() otherwise. A MessageID from a
+	// different partition must never be used here - it is only valid for the partition (ledger)
+	// it was read from.
+	startMessageIDFor := func(partition string) MessageID {
+		if tv.bootstrapPartitionStartIDs != nil {
+			if msgID, ok := tv.bootstrapPartitionStartIDs[partition]; ok {
+				return msgID
 			}
-			for reader.HasNext() {
-				msg, err := reader.Next(context.Background())
-				if err != nil {
-					tv.logger.Errorf("read next message failed for %s: %v", partition, err)
-				}
-				if msg != nil {
-					tv.handleMessage(msg)
+		}
+		return EarliestMessageID()
+	}
+
+	// Only count a partition into bootstrapTotal the first time it is seen; a partition that
+	// keeps failing to bootstrap is retried here on every call without inflating the total
+	// again, so BootstrapProgress() can't be made to look like it's still making progress.
+	atomic.AddInt32(&tv.bootstrapTotal, int32(tv.bootstrapAccounting.countNew(newPartitions)))
+
+	concurrency := tv.options.BootstrapConcurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	if concurrency > len(newPartitions) {
+		concurrency = len(newPartitions)
+	}
+
+	partitionCh := make(chan string, len(newPartitions))
+	for _, partition := range newPartitions {
+		partitionCh <- partition
+	}
+	close(partitionCh)
+
+	resultCh := make(chan bootstrapResult, len(newPartitions))
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for partition := range partitionCh {
+				result := tv.bootstrapPartition(ctx, partition, startMessageIDFor(partition))
+				if result.err == nil {
+					// Count and report each partition as soon as it is drained, so progress is
+					// visible while the other partitions are still bootstrapping.
+					atomic.AddInt32(&tv.bootstrapLoaded, 1)
+					if tv.options.OnPartitionReady != nil {
+						tv.options.OnPartitionReady(result.partition, result.entries)
+					}
 				}
+				resultCh <- result
 			}
-			ctx, cancelFunc := context.WithCancel(context.Background())
-			tv.cancelReaders[partition] = cancelReader{
-				reader:     reader,
-				cancelFunc: cancelFunc,
-			}
-			go tv.watchReaderForNewMessages(ctx, reader)
+		}()
+	}
+	workers.Wait()
+	close(resultCh)
+
+	// Register the successfully bootstrapped partitions and start watching them for new
+	// messages. A partition that failed to bootstrap (e.g. it was removed concurrently) is
+	// simply left out; it will be retried on the next partitionUpdateCheck if it still exists.
+	tv.readersMu.Lock()
+	defer tv.readersMu.Unlock()
+
+	var errMsgs []string
+	for result := range resultCh {
+		if result.err != nil {
+			errMsgs = append(errMsgs, result.err.Error())
+			continue
+		}
+
+		ctx, cancelFunc := context.WithCancel(context.Background())
+		tv.cancelReaders[result.partition] = cancelReader{
+			reader:     result.reader,
+			cancelFunc: cancelFunc,
 		}
+		go tv.watchReaderForNewMessages(ctx, result.reader)
 	}
 
+	if len(errMsgs) > 0 {
+		return fmt.Errorf("failed to bootstrap %d/%d partitions: %s",
+			len(errMsgs), len(newPartitions), strings.Join(errMsgs, "; "))
+	}
 	return nil
 }
 
+// bootstrapPartition creates a reader for partition, drains its backlog from startMessageID,
+// and reports the outcome without touching tv.cancelReaders; the caller registers the reader
+// once every worker has finished so the map is only ever mutated from one goroutine at a time.
+func (tv *TableViewImpl) bootstrapPartition(ctx context.Context, partition string, startMessageID MessageID) bootstrapResult {
+	ctx, span := tv.telemetry.startSpan(ctx, "bootstrap_partition",
+		attribute.String("pulsar.topic", tv.options.Topic),
+		attribute.String("pulsar.partition", partition))
+	defer span.End()
+	start := time.Now()
+
+	reader, err := newReader(tv.client, ReaderOptions{
+		Topic:          partition,
+		StartMessageID: startMessageID,
+		ReadCompacted:  true,
+		// TODO: Pooling?
+		Schema: tv.options.Schema,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return bootstrapResult{partition: partition, err: fmt.Errorf("create new reader failed for %s: %w", partition, err)}
+	}
+
+	entries := 0
+	for reader.HasNext() {
+		msg, err := reader.Next(ctx)
+		if err != nil {
+			tv.logger.Errorf("read next message failed for %s: %v", partition, err)
+		}
+		if msg != nil {
+			// handleMessage serializes all writes through dataMu/indexesMu, so concurrent
+			// bootstrap workers draining different partitions still produce a consistent view.
+			tv.handleMessage(msg)
+			entries++
+		}
+	}
+
+	tv.telemetry.recordBootstrapDuration(ctx, tv.options.Topic, partition, time.Since(start))
+
+	return bootstrapResult{partition: partition, reader: reader, entries: entries}
+}
+
+// BootstrapProgress reports how many of the partitions seen so far have completed their
+// initial backlog drain.
+func (tv *TableViewImpl) BootstrapProgress() (loaded, total int) {
+	return int(atomic.LoadInt32(&tv.bootstrapLoaded)), int(atomic.LoadInt32(&tv.bootstrapTotal))
+}
+
+// Filter implements TableView.
+func (tv *TableViewImpl) Filter(predicate func(string, interface{}) bool) TableView {
+	return newDerivedTableView(tv, predicate, 0, tv.logger)
+}
+
+// Window implements TableView.
+func (tv *TableViewImpl) Window(duration time.Duration) TableView {
+	return newDerivedTableView(tv, nil, duration, tv.logger)
+}
+
 func (tv *TableViewImpl) periodicPartitionUpdateCheck() {
 	for {
 		if err := tv.partitionUpdateCheck(); err != nil {
 			tv.logger.Errorf("failed to check for changes in number of partitions: %v", err)
 		}
+		if tv.options.TombstoneRetention > 0 {
+			tv.sweepTombstones()
+		}
 		select {
 		case <-tv.closedCh:
 			// If the TableViewImpl has been closed, stop checking for partition updates
@@ -163,58 +398,153 @@ func (tv *TableViewImpl) periodicPartitionUpdateCheck() {
 func (tv *TableViewImpl) Size() int {
 	tv.dataMu.Lock()
 	defer tv.dataMu.Unlock()
-	return len(tv.data)
+	// Tombstoned keys stay in tv.data with a nil value until sweepTombstones evicts them; like
+	// Keys and Entries, Size does not count them.
+	n := 0
+	for _, v := range tv.data.values {
+		if v != nil {
+			n++
+		}
+	}
+	return n
 }
 
 func (tv *TableViewImpl) IsEmpty() bool {
-	tv.dataMu.Lock()
-	defer tv.dataMu.Unlock()
 	return tv.Size() == 0
 }
 
 func (tv *TableViewImpl) ContainsKey(key string) bool {
 	tv.dataMu.Lock()
 	defer tv.dataMu.Unlock()
-	_, ok := tv.data[key]
-	return ok
+	v, ok := tv.data.Get(key)
+	return ok && v != nil
 }
 
 func (tv *TableViewImpl) Get(key string) interface{} {
 	tv.dataMu.Lock()
 	defer tv.dataMu.Unlock()
-	return tv.data[key]
+	v, _ := tv.data.Get(key)
+	return v
 }
 
 func (tv *TableViewImpl) Entries() map[string]interface{} {
 	tv.dataMu.Lock()
 	defer tv.dataMu.Unlock()
-	data := make(map[string]interface{}, len(tv.data))
-	for k, v := range tv.data {
-		data[k] = v
-	}
-	return tv.data
+	data := make(map[string]interface{}, tv.data.Len())
+	tv.data.ForEach(func(k string, v interface{}) bool {
+		if v != nil {
+			data[k] = v
+		}
+		return true
+	})
+	return data
 }
 
 func (tv *TableViewImpl) Keys() []string {
 	tv.dataMu.Lock()
 	defer tv.dataMu.Unlock()
-	keys := make([]string, len(tv.data))
-	i := 0
-	for k := range tv.data {
-		keys[i] = k
-		i++
-	}
+	keys := make([]string, 0, tv.data.Len())
+	tv.data.ForEach(func(k string, v interface{}) bool {
+		if v != nil {
+			keys = append(keys, k)
+		}
+		return true
+	})
 	return keys
 }
 
+// GetRange returns the entries with from <= key, and key < to when to is non-empty. The
+// result is a map, so it carries no key order.
+func (tv *TableViewImpl) GetRange(from, to string) map[string]interface{} {
+	tv.dataMu.Lock()
+	defer tv.dataMu.Unlock()
+	return tv.data.Range(from, to)
+}
+
+// GetByPrefix returns the entries whose key starts with prefix.
+func (tv *TableViewImpl) GetByPrefix(prefix string) map[string]interface{} {
+	tv.dataMu.Lock()
+	defer tv.dataMu.Unlock()
+	return tv.data.Prefix(prefix)
+}
+
+// AddIndex registers a secondary index backfilled from the current content of the TableView
+// and kept up to date as new messages are handled.
+func (tv *TableViewImpl) AddIndex(name string, extractor func(string, interface{}) []string) Index {
+	idx := newTableViewIndex(extractor)
+
+	tv.dataMu.Lock()
+	tv.data.ForEach(func(k string, v interface{}) bool {
+		idx.update(k, v)
+		return true
+	})
+	tv.dataMu.Unlock()
+
+	tv.indexesMu.Lock()
+	tv.indexes[name] = idx
+	tv.indexesMu.Unlock()
+
+	return idx
+}
+
 func (tv *TableViewImpl) ForEach(action func(string, interface{}) error) error {
 	tv.dataMu.Lock()
 	defer tv.dataMu.Unlock()
-	for k, v := range tv.data {
+	var actionErr error
+	tv.data.ForEach(func(k string, v interface{}) bool {
+		if v == nil {
+			return true
+		}
 		if err := action(k, v); err != nil {
-			return err
+			actionErr = err
+			return false
+		}
+		return true
+	})
+	return actionErr
+}
+
+// sweepTombstones evicts keys that were tombstoned (a nil value, published as an
+// empty-payload message) more than TableViewOptions.TombstoneRetention ago, reclaiming the
+// memory held for keys that have been deleted. A live key is never evicted by age alone: an
+// entry that simply hasn't changed recently is still the current value in the compacted
+// topic and must stay visible to Get/ContainsKey/ForEach.
+func (tv *TableViewImpl) sweepTombstones() {
+	tv.dataMu.Lock()
+	defer tv.dataMu.Unlock()
+
+	horizon := time.Now().Add(-tv.options.TombstoneRetention)
+	for k, updatedAt := range tv.lastUpdated {
+		if updatedAt.Before(horizon) {
+			if v, ok := tv.data.Get(k); !ok || v != nil {
+				continue
+			}
+			tv.data.Delete(k)
+			delete(tv.rawData, k)
+			delete(tv.lastUpdated, k)
 		}
 	}
+}
+
+// Delete publishes an empty-payload message for key, marking it as deleted in the compacted
+// topic that backs this TableView.
+func (tv *TableViewImpl) Delete(key string) error {
+	producer, err := tv.client.CreateProducer(ProducerOptions{
+		Topic:  tv.options.Topic,
+		Schema: tv.options.Schema,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create producer to delete key %q: %w", key, err)
+	}
+	defer producer.Close()
+
+	_, err = producer.Send(context.Background(), &ProducerMessage{
+		Key:     key,
+		Payload: nil,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish tombstone for key %q: %w", key, err)
+	}
 	return nil
 }
 
@@ -226,10 +556,56 @@ func (tv *TableViewImpl) ForEachAndListen(action func(string, interface{}) error
 		return err
 	}
 
-	tv.listeners = append(tv.listeners, action)
+	tv.addListenerLocked(action)
 	return nil
 }
 
+// listenOnly registers action to be called for every new entry that is added, updated, or
+// tombstoned, without replaying the entries already held by the TableView. It underlies
+// TypedTableView.Listen and TypedTableView.Watch, which replay existing entries (if at all)
+// through their own typed ForEach instead.
+func (tv *TableViewImpl) listenOnly(action func(string, interface{}) error) {
+	tv.listenersMu.Lock()
+	defer tv.listenersMu.Unlock()
+	tv.addListenerLocked(action)
+}
+
+// forEachAndListenRemovable behaves like ForEachAndListen, but additionally returns a function
+// that removes the registered listener again. It underlies derivedTableView, so a Filter/Window
+// view backed by this TableView can detach itself on Close instead of being kept alive forever
+// by tv's strong reference to its listener closure.
+func (tv *TableViewImpl) forEachAndListenRemovable(
+	action func(string, interface{}) error,
+) (remove func(), err error) {
+	tv.listenersMu.Lock()
+	defer tv.listenersMu.Unlock()
+
+	if err := tv.ForEach(action); err != nil {
+		return nil, err
+	}
+
+	id := tv.addListenerLocked(action)
+	return func() { tv.removeListener(id) }, nil
+}
+
+// addListenerLocked registers action under the given id and returns it. tv.listenersMu must
+// already be held.
+func (tv *TableViewImpl) addListenerLocked(action func(string, interface{}) error) int {
+	if tv.listeners == nil {
+		tv.listeners = make(map[int]func(string, interface{}) error)
+	}
+	id := tv.nextListenerID
+	tv.nextListenerID++
+	tv.listeners[id] = action
+	return id
+}
+
+func (tv *TableViewImpl) removeListener(id int) {
+	tv.listenersMu.Lock()
+	defer tv.listenersMu.Unlock()
+	delete(tv.listeners, id)
+}
+
 func (tv *TableViewImpl) Close() {
 	tv.readersMu.Lock()
 	defer tv.readersMu.Unlock()
@@ -239,28 +615,153 @@ func (tv *TableViewImpl) Close() {
 		for _, cancelReader := range tv.cancelReaders {
 			cancelReader.reader.Close()
 		}
+		if err := tv.telemetry.Close(); err != nil {
+			tv.logger.Errorf("failed to unregister tableview.entries gauge callback: %v", err)
+		}
 		close(tv.closedCh)
 	}
 }
 
 func (tv *TableViewImpl) handleMessage(msg Message) {
+	ctx := tv.telemetry.extractMessageContext(context.Background(), msg)
+	ctx, span := tv.telemetry.startSpan(ctx, "handle_message",
+		attribute.String("pulsar.topic", tv.options.Topic),
+		attribute.String("pulsar.partition", msg.Topic()),
+		attribute.String("tableview.key", msg.Key()))
+	defer span.End()
+
+	tv.telemetry.recordUpdate(ctx, msg.Topic())
+	tv.telemetry.recordReaderLag(ctx, msg.Topic(), msg.PublishTime())
+
 	tv.dataMu.Lock()
-	defer tv.dataMu.Unlock()
 
 	payload := reflect.New(tv.options.SchemaValueType)
 	if len(msg.Payload()) == 0 {
-		delete(tv.data, msg.Key())
+		// Keep a tombstone entry around (with a nil value) instead of deleting it outright, so
+		// ContainsKey/Get observe the deletion immediately while sweepTombstones reclaims the
+		// map entry once TombstoneRetention has elapsed.
+		tv.data.Set(msg.Key(), nil)
+		tv.rawData[msg.Key()] = nil
 	} else {
 		if err := msg.GetSchemaValue(payload.Interface()); err != nil {
+			span.RecordError(err)
 			tv.logger.Errorf("msg.GetSchemaValue() failed with %v; msg is %v", err, msg)
 		}
-		tv.data[msg.Key()] = reflect.Indirect(payload).Interface()
+		tv.data.Set(msg.Key(), reflect.Indirect(payload).Interface())
+		tv.rawData[msg.Key()] = msg.Payload()
+	}
+	tv.lastUpdated[msg.Key()] = time.Now()
+	tv.partitionMessageIDs[msg.Topic()] = msg.ID()
+
+	newValue, _ := tv.data.Get(msg.Key())
+	// dataMu is released before listenersMu is taken below: ForEachAndListen and
+	// forEachAndListenRemovable take listenersMu first and then dataMu (via ForEach) to seed and
+	// register atomically, so taking them in the opposite order here would be a lock-order
+	// inversion - a registration in progress on one goroutine and a message being handled on
+	// another could each end up waiting on the lock the other already holds.
+	tv.dataMu.Unlock()
+
+	tv.indexesMu.Lock()
+	for _, idx := range tv.indexes {
+		idx.update(msg.Key(), newValue)
 	}
+	tv.indexesMu.Unlock()
 
+	tv.listenersMu.Lock()
+	listeners := make([]func(string, interface{}) error, 0, len(tv.listeners))
 	for _, listener := range tv.listeners {
-		if err := listener(msg.Key(), reflect.Indirect(payload).Interface()); err != nil {
+		listeners = append(listeners, listener)
+	}
+	tv.listenersMu.Unlock()
+
+	for _, listener := range listeners {
+		// listenerCtx carries the span linked to the producer's trace via ctx, but the listener
+		// signature below takes no context.Context, so listenerCtx only scopes TableView's own
+		// "listener" span - it is not visible to the listener callback itself.
+		listenerCtx, listenerSpan := tv.telemetry.startSpan(ctx, "listener",
+			attribute.String("tableview.key", msg.Key()))
+		if err := listener(msg.Key(), newValue); err != nil {
+			listenerSpan.RecordError(err)
+			tv.telemetry.recordListenerError(listenerCtx, msg.Topic())
 			tv.logger.Errorf("table view listener failed for %v: %v", msg, err)
 		}
+		listenerSpan.End()
+	}
+}
+
+// seedFromSnapshot populates tv.data, tv.rawData and tv.lastUpdated from a snapshot loaded
+// via TableViewOptions.SnapshotStore, ahead of the initial partitionUpdateCheck.
+func (tv *TableViewImpl) seedFromSnapshot(snapshot map[string][]byte) {
+	tv.dataMu.Lock()
+	defer tv.dataMu.Unlock()
+
+	now := time.Now()
+	for key, raw := range snapshot {
+		tv.rawData[key] = raw
+		tv.lastUpdated[key] = now
+		if len(raw) == 0 {
+			tv.data.Set(key, nil)
+			continue
+		}
+		tv.data.Set(key, tv.decodeValue(raw))
+	}
+}
+
+// decodeValue decodes raw payload bytes into tv.options.SchemaValueType, mirroring the
+// decoding handleMessage performs via msg.GetSchemaValue().
+func (tv *TableViewImpl) decodeValue(raw []byte) interface{} {
+	payload := reflect.New(tv.options.SchemaValueType)
+	if tv.options.Schema != nil {
+		if err := tv.options.Schema.Decode(raw, payload.Interface()); err != nil {
+			tv.logger.Errorf("failed to decode snapshot value: %v", err)
+		}
+	} else if rawType := reflect.TypeOf(raw); rawType.AssignableTo(tv.options.SchemaValueType) {
+		reflect.Indirect(payload).Set(reflect.ValueOf(raw))
+	} else {
+		// Without a Schema, a snapshot can only be rehydrated as raw []byte. Seeding e.g. a
+		// CreateTypedTableView[V] with a non-[]byte V and no explicit Schema would otherwise
+		// panic here on the type mismatch; log and fall back to SchemaValueType's zero value
+		// instead, the same way a Schema.Decode error above is handled.
+		tv.logger.Errorf(
+			"failed to decode snapshot value: SchemaValueType %s is not []byte and no Schema was "+
+				"set; set TableViewOptions.Schema whenever SnapshotStore is combined with a "+
+				"non-[]byte value type", tv.options.SchemaValueType)
+	}
+	return reflect.Indirect(payload).Interface()
+}
+
+// periodicSnapshot persists a new snapshot of the TableView content on
+// TableViewOptions.SnapshotInterval, until the TableView is closed.
+func (tv *TableViewImpl) periodicSnapshot() {
+	for {
+		select {
+		case <-tv.closedCh:
+			return
+		case <-time.After(tv.options.SnapshotInterval):
+			tv.takeSnapshot()
+		}
+	}
+}
+
+func (tv *TableViewImpl) takeSnapshot() {
+	tv.dataMu.Lock()
+	partitionMessageIDs := make(map[string]MessageID, len(tv.partitionMessageIDs))
+	for partition, msgID := range tv.partitionMessageIDs {
+		partitionMessageIDs[partition] = msgID
+	}
+	snapshot := make(map[string][]byte, len(tv.rawData))
+	for k, v := range tv.rawData {
+		snapshot[k] = v
+	}
+	tv.dataMu.Unlock()
+
+	if len(partitionMessageIDs) == 0 {
+		// No messages have been processed yet; nothing to snapshot.
+		return
+	}
+
+	if err := tv.options.SnapshotStore.Store(tv.options.Topic, snapshot, partitionMessageIDs); err != nil {
+		tv.logger.Errorf("failed to store snapshot for topic %s: %v", tv.options.Topic, err)
 	}
 }
 
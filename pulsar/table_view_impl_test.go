@@ -0,0 +1,118 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar/log"
+	"github.com/sirupsen/logrus"
+)
+
+// newTestTableView builds a TableViewImpl directly, bypassing newTableView (which requires a
+// live *client), so the in-memory bookkeeping it implements can be exercised in isolation.
+func newTestTableView(t *testing.T, retention time.Duration) *TableViewImpl {
+	t.Helper()
+
+	tv := &TableViewImpl{
+		options:       TableViewOptions{TombstoneRetention: retention},
+		data:          newOrderedData(),
+		rawData:       make(map[string][]byte),
+		lastUpdated:   make(map[string]time.Time),
+		cancelReaders: make(map[string]cancelReader),
+		listeners:     make(map[int]func(string, interface{}) error),
+		indexes:       make(map[string]*tableViewIndex),
+		logger:        log.NewLoggerWithLogrus(logrus.StandardLogger()),
+		closedCh:      make(chan struct{}),
+	}
+
+	telemetry, err := newTableViewTelemetry(tv, tv.options)
+	if err != nil {
+		t.Fatalf("newTableViewTelemetry() error = %v", err)
+	}
+	tv.telemetry = telemetry
+
+	return tv
+}
+
+func TestSweepTombstonesOnlyEvictsTombstonedKeys(t *testing.T) {
+	tv := newTestTableView(t, 10*time.Millisecond)
+
+	tv.data.Set("live", "unchanged-value")
+	tv.data.Set("deleted", nil)
+
+	stale := time.Now().Add(-time.Hour)
+	tv.lastUpdated["live"] = stale
+	tv.lastUpdated["deleted"] = stale
+
+	tv.sweepTombstones()
+
+	if v, ok := tv.data.Get("live"); !ok || v != "unchanged-value" {
+		t.Fatalf("expected stale-but-live key to survive the sweep, got value=%v ok=%v", v, ok)
+	}
+	if _, ok := tv.data.Get("deleted"); ok {
+		t.Fatalf("expected tombstoned key to be evicted by the sweep")
+	}
+	if _, ok := tv.lastUpdated["live"]; !ok {
+		t.Fatalf("expected live key's lastUpdated entry to be left in place")
+	}
+}
+
+func TestSweepTombstonesKeepsRecentTombstones(t *testing.T) {
+	tv := newTestTableView(t, time.Hour)
+
+	tv.data.Set("deleted", nil)
+	tv.lastUpdated["deleted"] = time.Now()
+
+	tv.sweepTombstones()
+
+	if _, ok := tv.data.Get("deleted"); !ok {
+		t.Fatalf("expected tombstone within the retention window to survive the sweep")
+	}
+}
+
+func TestDecodeValueWithoutSchemaFallsBackToZeroValueOnTypeMismatch(t *testing.T) {
+	tv := newTestTableView(t, 0)
+	tv.options.SchemaValueType = reflect.TypeOf(int(0))
+
+	got := tv.decodeValue([]byte("not an int"))
+
+	if got != 0 {
+		t.Fatalf("decodeValue() with a type mismatch = %v, want the zero value 0", got)
+	}
+}
+
+func TestBootstrapAccountingCountsEachPartitionOnce(t *testing.T) {
+	b := newBootstrapAccounting()
+
+	if n := b.countNew([]string{"p0", "p1"}); n != 2 {
+		t.Fatalf("countNew() on first sight = %d, want 2", n)
+	}
+
+	// p1 is still failing to bootstrap and is offered again on the next periodic check,
+	// alongside a genuinely new partition p2; only p2 should count towards the total.
+	if n := b.countNew([]string{"p1", "p2"}); n != 1 {
+		t.Fatalf("countNew() on retry = %d, want 1", n)
+	}
+
+	if n := b.countNew([]string{"p0", "p1", "p2"}); n != 0 {
+		t.Fatalf("countNew() with only previously-seen partitions = %d, want 0", n)
+	}
+}
@@ -0,0 +1,96 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import "sync"
+
+// Index is a secondary index derived from a TableView's entries, registered via
+// TableView.AddIndex. It lets callers look up primary keys by a derived index term without
+// scanning every entry in the TableView.
+type Index interface {
+	// Lookup returns the primary keys whose extracted index terms currently include
+	// indexKey, in no particular order.
+	Lookup(indexKey string) []string
+}
+
+// tableViewIndex maintains a byTerm -> set-of-primary-keys mapping, kept up to date as
+// TableViewImpl.handleMessage processes updates and tombstones.
+type tableViewIndex struct {
+	extractor func(key string, value interface{}) []string
+
+	mu     sync.Mutex
+	byTerm map[string]map[string]bool // index term -> set of primary keys
+	byKey  map[string][]string        // primary key -> index terms currently mapped for it
+}
+
+func newTableViewIndex(extractor func(key string, value interface{}) []string) *tableViewIndex {
+	return &tableViewIndex{
+		extractor: extractor,
+		byTerm:    make(map[string]map[string]bool),
+		byKey:     make(map[string][]string),
+	}
+}
+
+// Lookup implements Index.
+func (idx *tableViewIndex) Lookup(indexKey string) []string {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	keys := idx.byTerm[indexKey]
+	result := make([]string, 0, len(keys))
+	for k := range keys {
+		result = append(result, k)
+	}
+	return result
+}
+
+// update recomputes the index terms for key given its current value (value is nil for a
+// tombstoned key), removing any previously extracted terms that no longer apply.
+func (idx *tableViewIndex) update(key string, value interface{}) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for _, term := range idx.byKey[key] {
+		if set := idx.byTerm[term]; set != nil {
+			delete(set, key)
+			if len(set) == 0 {
+				delete(idx.byTerm, term)
+			}
+		}
+	}
+	delete(idx.byKey, key)
+
+	if value == nil {
+		return
+	}
+
+	terms := idx.extractor(key, value)
+	if len(terms) == 0 {
+		return
+	}
+
+	idx.byKey[key] = terms
+	for _, term := range terms {
+		set := idx.byTerm[term]
+		if set == nil {
+			set = make(map[string]bool)
+			idx.byTerm[term] = set
+		}
+		set[key] = true
+	}
+}
@@ -0,0 +1,84 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import (
+	"sort"
+	"testing"
+)
+
+func extractTags(key string, value interface{}) []string {
+	tags, _ := value.([]string)
+	return tags
+}
+
+func TestTableViewIndexLookupAndUpdate(t *testing.T) {
+	idx := newTableViewIndex(extractTags)
+
+	idx.update("k1", []string{"red", "small"})
+	idx.update("k2", []string{"red", "large"})
+
+	got := idx.Lookup("red")
+	sort.Strings(got)
+	if want := []string{"k1", "k2"}; !equalStringSlices(got, want) {
+		t.Fatalf("Lookup(red) = %v, want %v", got, want)
+	}
+
+	if got := idx.Lookup("small"); !equalStringSlices(got, []string{"k1"}) {
+		t.Fatalf("Lookup(small) = %v, want [k1]", got)
+	}
+}
+
+func TestTableViewIndexUpdateRemovesStaleTerms(t *testing.T) {
+	idx := newTableViewIndex(extractTags)
+
+	idx.update("k1", []string{"red"})
+	idx.update("k1", []string{"blue"})
+
+	if got := idx.Lookup("red"); len(got) != 0 {
+		t.Fatalf("Lookup(red) after re-tagging k1 = %v, want empty", got)
+	}
+	if got := idx.Lookup("blue"); !equalStringSlices(got, []string{"k1"}) {
+		t.Fatalf("Lookup(blue) = %v, want [k1]", got)
+	}
+}
+
+func TestTableViewIndexUpdateWithNilValueTombstonesKey(t *testing.T) {
+	idx := newTableViewIndex(extractTags)
+
+	idx.update("k1", []string{"red"})
+	idx.update("k1", nil)
+
+	if got := idx.Lookup("red"); len(got) != 0 {
+		t.Fatalf("Lookup(red) after tombstoning k1 = %v, want empty", got)
+	}
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
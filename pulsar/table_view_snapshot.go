@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SnapshotStore lets a TableView persist and restore its in-memory state across restarts,
+// so the initial reader.HasNext()/Next() drain over a large compacted topic does not have
+// to be repeated on every process start.
+type SnapshotStore interface {
+	// Load returns the previously stored snapshot for topic, along with the MessageID that
+	// the snapshot was taken at, for each partition. A MessageID is only valid for the
+	// partition it was read from, so each partition's reader must be seeded from its own entry
+	// in that map rather than from any other partition's. Load should return (nil, nil, nil)
+	// if no snapshot exists yet.
+	Load(topic string) (map[string][]byte, map[string]MessageID, error)
+
+	// Store persists snapshot for topic, recorded at partitionMessageIDs, which gives the
+	// MessageID the snapshot was taken at for each partition.
+	Store(topic string, snapshot map[string][]byte, partitionMessageIDs map[string]MessageID) error
+}
+
+// FileSystemSnapshotStore is a SnapshotStore backed by a single file per topic on the local
+// file system. It is intended for simple single-process deployments; callers that shard
+// TableViews across machines should provide their own SnapshotStore implementation.
+type FileSystemSnapshotStore struct {
+	// Dir is the directory that snapshot files are written to and read from. It is created
+	// on first Store if it does not already exist.
+	Dir string
+
+	mu sync.Mutex
+}
+
+type fileSystemSnapshot struct {
+	// PartitionMessageIDData holds each partition's serialized MessageID, keyed by partition
+	// topic name. A MessageID can't be gob-encoded directly, so it's stored via Serialize/
+	// DeserializeMessageID.
+	PartitionMessageIDData map[string][]byte
+	Entries                map[string][]byte
+}
+
+func (s *FileSystemSnapshotStore) path(topic string) string {
+	return filepath.Join(s.Dir, url.QueryEscape(topic)+".snapshot")
+}
+
+// Load implements SnapshotStore.
+func (s *FileSystemSnapshotStore) Load(topic string) (map[string][]byte, map[string]MessageID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(topic))
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to read snapshot for topic %s: %w", topic, err)
+	}
+
+	var snap fileSystemSnapshot
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&snap); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode snapshot for topic %s: %w", topic, err)
+	}
+
+	partitionMessageIDs := make(map[string]MessageID, len(snap.PartitionMessageIDData))
+	for partition, raw := range snap.PartitionMessageIDData {
+		msgID, err := DeserializeMessageID(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"failed to decode snapshot message id for topic %s partition %s: %w", topic, partition, err)
+		}
+		partitionMessageIDs[partition] = msgID
+	}
+
+	return snap.Entries, partitionMessageIDs, nil
+}
+
+// Store implements SnapshotStore.
+func (s *FileSystemSnapshotStore) Store(
+	topic string, snapshot map[string][]byte, partitionMessageIDs map[string]MessageID,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot dir %s: %w", s.Dir, err)
+	}
+
+	partitionMessageIDData := make(map[string][]byte, len(partitionMessageIDs))
+	for partition, msgID := range partitionMessageIDs {
+		partitionMessageIDData[partition] = msgID.Serialize()
+	}
+
+	var buf bytes.Buffer
+	snap := fileSystemSnapshot{
+		PartitionMessageIDData: partitionMessageIDData,
+		Entries:                snapshot,
+	}
+	if err := gob.NewEncoder(&buf).Encode(snap); err != nil {
+		return fmt.Errorf("failed to encode snapshot for topic %s: %w", topic, err)
+	}
+
+	tmp := s.path(topic) + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot for topic %s: %w", topic, err)
+	}
+	return os.Rename(tmp, s.path(topic))
+}
@@ -0,0 +1,191 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tableViewInstrumentationName = "github.com/apache/pulsar-client-go/pulsar/tableview"
+
+// tableViewTelemetry holds the tracer, meter and instruments used to instrument a
+// TableViewImpl. Its tracer/meter default to the global OpenTelemetry providers, so a
+// TableView is instrumented even when TableViewOptions.TracerProvider/MeterProvider are left
+// unset, consistent with how the rest of an application that calls otel.SetTracerProvider
+// picks up instrumentation automatically.
+type tableViewTelemetry struct {
+	tracer trace.Tracer
+
+	updatesTotal        metric.Int64Counter
+	listenerErrorsTotal metric.Int64Counter
+	bootstrapDuration   metric.Float64Histogram
+	partitionReaderLag  metric.Float64Histogram
+
+	// entriesGaugeReg is the registration backing the tableview.entries observable gauge
+	// callback. It must be unregistered from Close, or the MeterProvider keeps polling it (and
+	// keeping the TableViewImpl it closes over alive) for as long as the MeterProvider lives.
+	entriesGaugeReg metric.Registration
+}
+
+func newTableViewTelemetry(tv *TableViewImpl, options TableViewOptions) (*tableViewTelemetry, error) {
+	tp := options.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	mp := options.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	meter := mp.Meter(tableViewInstrumentationName)
+	t := &tableViewTelemetry{
+		tracer: tp.Tracer(tableViewInstrumentationName),
+	}
+
+	var err error
+	entriesGauge, err := meter.Int64ObservableGauge(
+		"tableview.entries",
+		metric.WithDescription("Number of entries currently held by the TableView"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tableview.entries gauge: %w", err)
+	}
+
+	// Registered (rather than passed via metric.WithInt64Callback) so the registration can be
+	// unregistered from Close, detaching tv from the MeterProvider once the TableView is done.
+	t.entriesGaugeReg, err = meter.RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(entriesGauge, int64(tv.Size()), metric.WithAttributes(attribute.String("pulsar.topic", options.Topic)))
+			return nil
+		},
+		entriesGauge,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register tableview.entries gauge callback: %w", err)
+	}
+
+	if t.updatesTotal, err = meter.Int64Counter(
+		"tableview.updates_total",
+		metric.WithDescription("Number of messages applied to the TableView, by partition"),
+	); err != nil {
+		return nil, fmt.Errorf("failed to create tableview.updates_total counter: %w", err)
+	}
+
+	if t.listenerErrorsTotal, err = meter.Int64Counter(
+		"tableview.listener_errors_total",
+		metric.WithDescription("Number of errors returned by TableView listeners"),
+	); err != nil {
+		return nil, fmt.Errorf("failed to create tableview.listener_errors_total counter: %w", err)
+	}
+
+	if t.bootstrapDuration, err = meter.Float64Histogram(
+		"tableview.bootstrap_duration_seconds",
+		metric.WithDescription("Time taken to drain a partition's initial backlog"),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, fmt.Errorf("failed to create tableview.bootstrap_duration_seconds histogram: %w", err)
+	}
+
+	if t.partitionReaderLag, err = meter.Float64Histogram(
+		"tableview.partition_reader_lag_seconds",
+		metric.WithDescription("Time between a message's publish time and when the TableView observed it"),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, fmt.Errorf("failed to create tableview.partition_reader_lag_seconds histogram: %w", err)
+	}
+
+	return t, nil
+}
+
+// Close unregisters the tableview.entries gauge callback, so the MeterProvider stops polling
+// it and no longer holds tv, which it closes over, once the TableView is closed.
+func (t *tableViewTelemetry) Close() error {
+	if t.entriesGaugeReg == nil {
+		return nil
+	}
+	return t.entriesGaugeReg.Unregister()
+}
+
+// messagePropertiesCarrier adapts a message's string properties to otel's TextMapCarrier so
+// that a producer-set "traceparent" property can be extracted into a span context.
+type messagePropertiesCarrier map[string]string
+
+func (c messagePropertiesCarrier) Get(key string) string {
+	return c[key]
+}
+
+func (c messagePropertiesCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c messagePropertiesCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// extractMessageContext returns ctx augmented with the span context carried in msg's
+// "traceparent"/"tracestate" properties, if any, so that TableView's own internal
+// "pulsar.tableview.handle_message" and "pulsar.tableview.listener" spans join the producer's
+// trace. The TableView listener signature (func(string, interface{}) error) takes no
+// context.Context, so this links only TableView's internal spans - it does not give a
+// listener's own callback body a way to observe or join the extracted span.
+func (t *tableViewTelemetry) extractMessageContext(ctx context.Context, msg Message) context.Context {
+	return propagation.TraceContext{}.Extract(ctx, messagePropertiesCarrier(msg.Properties()))
+}
+
+// startSpan starts a child span named "pulsar.tableview.<op>" with the given attributes.
+func (t *tableViewTelemetry) startSpan(
+	ctx context.Context, op string, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, "pulsar.tableview."+op, trace.WithAttributes(attrs...))
+}
+
+func (t *tableViewTelemetry) recordBootstrapDuration(ctx context.Context, topic, partition string, d time.Duration) {
+	t.bootstrapDuration.Record(ctx, d.Seconds(), metric.WithAttributes(
+		attribute.String("pulsar.topic", topic),
+		attribute.String("pulsar.partition", partition),
+	))
+}
+
+func (t *tableViewTelemetry) recordUpdate(ctx context.Context, partition string) {
+	t.updatesTotal.Add(ctx, 1, metric.WithAttributes(attribute.String("pulsar.partition", partition)))
+}
+
+func (t *tableViewTelemetry) recordListenerError(ctx context.Context, partition string) {
+	t.listenerErrorsTotal.Add(ctx, 1, metric.WithAttributes(attribute.String("pulsar.partition", partition)))
+}
+
+func (t *tableViewTelemetry) recordReaderLag(ctx context.Context, partition string, publishTime time.Time) {
+	if publishTime.IsZero() {
+		return
+	}
+	t.partitionReaderLag.Record(ctx, time.Since(publishTime).Seconds(), metric.WithAttributes(
+		attribute.String("pulsar.partition", partition),
+	))
+}
@@ -0,0 +1,39 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import "testing"
+
+func TestTableViewTelemetryCloseUnregistersEntriesGauge(t *testing.T) {
+	tv := &TableViewImpl{data: newOrderedData()}
+	telemetry, err := newTableViewTelemetry(tv, TableViewOptions{Topic: "my-topic"})
+	if err != nil {
+		t.Fatalf("newTableViewTelemetry() error = %v", err)
+	}
+	if telemetry.entriesGaugeReg == nil {
+		t.Fatalf("expected entriesGaugeReg to be set after newTableViewTelemetry()")
+	}
+
+	if err := telemetry.Close(); err != nil {
+		t.Fatalf("telemetry.Close() error = %v", err)
+	}
+	// Unregister is documented to be safe to call more than once.
+	if err := telemetry.Close(); err != nil {
+		t.Fatalf("telemetry.Close() second call error = %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/apache/pulsar-client-go/pulsar/log"
+)
+
+// TypedTableView is a compile-time type-safe alternative to TableView, avoiding the
+// interface{} casts every TableView call site otherwise needs. K is constrained to ~string
+// since that is the only key type TableView's underlying compacted-topic model supports.
+type TypedTableView[K ~string, V any] interface {
+	// Size returns the number of key/value entries currently held by the TypedTableView.
+	Size() int
+
+	// IsEmpty returns true if the TypedTableView has no entries.
+	IsEmpty() bool
+
+	// ContainsKey returns true if the TypedTableView holds an entry for the given key.
+	ContainsKey(key K) bool
+
+	// Get returns the value associated with key and true, or the zero value and false if key
+	// is not present.
+	Get(key K) (V, bool)
+
+	// ForEach iterates over the entries currently held by the TypedTableView.
+	ForEach(action func(K, V) error) error
+
+	// Listen registers action to be called for every new entry that is added or updated.
+	// Unlike TableView.ForEachAndListen, it does not also replay the existing entries; combine
+	// it with ForEach for that.
+	Listen(action func(K, V) error)
+
+	// Watch returns a channel that receives a TableViewEvent for every entry added, updated,
+	// or tombstoned from this point on, for push-style consumers. The channel is closed when
+	// the TypedTableView is closed.
+	//
+	// The channel is buffered but not unbounded: if the consumer falls behind, further events
+	// are dropped rather than blocking message processing for the whole TableView. Call
+	// Dropped to detect this.
+	Watch() <-chan TableViewEvent[V]
+
+	// Dropped returns the number of events dropped from the channel returned by Watch because
+	// the consumer was not keeping up. A non-zero value means the stream of events observed on
+	// that channel is not complete.
+	Dropped() int64
+
+	// Close closes the TypedTableView and stops the background readers.
+	Close()
+}
+
+// TableViewEvent is a single update delivered on the channel returned by
+// TypedTableView.Watch.
+type TableViewEvent[V any] struct {
+	// Key is the key that was added, updated, or tombstoned.
+	Key string
+
+	// Value is the new value for Key. It is the zero value of V when Tombstone is true.
+	Value V
+
+	// Tombstone is true when Key was deleted rather than updated.
+	Tombstone bool
+}
+
+// TypedTableViewOptions specifies the options for creating a TypedTableView. It mirrors
+// TableViewOptions, but does not need SchemaValueType since V's type is known at compile time.
+type TypedTableViewOptions[V any] struct {
+	// Topic specifies the topic this TypedTableView will be backed by. This is required.
+	Topic string
+
+	// Schema specifies the schema used to decode the messages. Defaults to the bytes schema.
+	Schema Schema
+
+	// AutoUpdatePartitionsInterval specifies the interval to check for changes in the number
+	// of partitions of the backing topic. Defaults to 1 minute.
+	AutoUpdatePartitionsInterval time.Duration
+
+	// TombstoneRetention specifies how long a key is retained after being tombstoned before
+	// it is evicted to reclaim memory. A zero value disables eviction.
+	TombstoneRetention time.Duration
+
+	// SnapshotStore, when set, is used to load and periodically persist a snapshot of the
+	// TypedTableView content, reducing cold-start latency.
+	SnapshotStore SnapshotStore
+
+	// SnapshotInterval specifies how often a new snapshot is persisted via SnapshotStore.
+	SnapshotInterval time.Duration
+
+	// BootstrapConcurrency specifies how many partitions are drained concurrently. Defaults to 4.
+	BootstrapConcurrency int
+
+	// OnPartitionReady, when set, is invoked once a partition has finished its initial backlog
+	// drain, with the number of entries read from that partition.
+	OnPartitionReady func(partition string, entries int)
+
+	// TracerProvider specifies the OpenTelemetry TracerProvider used to instrument the
+	// TypedTableView. Defaults to otel.GetTracerProvider().
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider specifies the OpenTelemetry MeterProvider used to instrument the
+	// TypedTableView. Defaults to otel.GetMeterProvider().
+	MeterProvider metric.MeterProvider
+
+	// Logger specifies the logger used by the TypedTableView. Defaults to logrus.StandardLogger().
+	Logger log.Logger
+}
+
+// CreateTypedTableView creates a TypedTableView for V, internally sharing the same
+// TableViewImpl machinery as CreateTableView. c must be a *client returned by NewClient.
+func CreateTypedTableView[V any](c Client, options TypedTableViewOptions[V]) (TypedTableView[string, V], error) {
+	internalClient, ok := c.(*client)
+	if !ok {
+		return nil, newError(InvalidConfiguration, "CreateTypedTableView requires a *client created via NewClient")
+	}
+
+	untyped, err := newTableView(internalClient, TableViewOptions{
+		Topic:                        options.Topic,
+		SchemaValueType:              reflect.TypeOf((*V)(nil)).Elem(),
+		Schema:                       options.Schema,
+		AutoUpdatePartitionsInterval: options.AutoUpdatePartitionsInterval,
+		TombstoneRetention:           options.TombstoneRetention,
+		SnapshotStore:                options.SnapshotStore,
+		SnapshotInterval:             options.SnapshotInterval,
+		BootstrapConcurrency:         options.BootstrapConcurrency,
+		OnPartitionReady:             options.OnPartitionReady,
+		TracerProvider:               options.TracerProvider,
+		MeterProvider:                options.MeterProvider,
+		Logger:                       options.Logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &typedTableView[V]{inner: untyped.(*TableViewImpl)}, nil
+}
+
+// typedTableView adapts a *TableViewImpl to the TypedTableView[string, V] API, converting
+// the interface{} values handleMessage stores back to V at the boundary instead of forcing
+// every call site to do so.
+type typedTableView[V any] struct {
+	inner *TableViewImpl
+
+	// dropped counts events dropped from the channel(s) returned by Watch because a consumer
+	// fell behind. Accessed with sync/atomic since it is written from handleMessage's listener
+	// dispatch and read from Dropped.
+	dropped atomic.Int64
+}
+
+func (t *typedTableView[V]) Size() int { return t.inner.Size() }
+
+func (t *typedTableView[V]) IsEmpty() bool { return t.inner.IsEmpty() }
+
+func (t *typedTableView[V]) ContainsKey(key string) bool { return t.inner.ContainsKey(key) }
+
+func (t *typedTableView[V]) Get(key string) (V, bool) {
+	var zero V
+	value := t.inner.Get(key)
+	if value == nil {
+		return zero, false
+	}
+	v, ok := value.(V)
+	if !ok {
+		return zero, false
+	}
+	return v, true
+}
+
+func (t *typedTableView[V]) ForEach(action func(string, V) error) error {
+	return t.inner.ForEach(func(key string, value interface{}) error {
+		v, ok := value.(V)
+		if !ok {
+			return nil
+		}
+		return action(key, v)
+	})
+}
+
+func (t *typedTableView[V]) Listen(action func(string, V) error) {
+	t.inner.listenOnly(func(key string, value interface{}) error {
+		v, ok := value.(V)
+		if !ok {
+			return nil
+		}
+		return action(key, v)
+	})
+}
+
+func (t *typedTableView[V]) Watch() <-chan TableViewEvent[V] {
+	ch := make(chan TableViewEvent[V], 64)
+	t.inner.listenOnly(func(key string, value interface{}) error {
+		event := TableViewEvent[V]{Key: key}
+		if value == nil {
+			event.Tombstone = true
+		} else if v, ok := value.(V); ok {
+			event.Value = v
+		}
+		select {
+		case ch <- event:
+		default:
+			// Drop the event rather than block message processing if the consumer is slow.
+			// handleMessage calls listeners synchronously on the reader goroutine, so blocking
+			// here would stall that partition's reader and the listeners after this one.
+			t.dropped.Add(1)
+		}
+		return nil
+	})
+	go func() {
+		<-t.inner.closedCh
+		close(ch)
+	}()
+	return ch
+}
+
+func (t *typedTableView[V]) Dropped() int64 { return t.dropped.Load() }
+
+func (t *typedTableView[V]) Close() { t.inner.Close() }
@@ -0,0 +1,44 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pulsar
+
+import "testing"
+
+func TestTypedTableViewWatchReportsDroppedEvents(t *testing.T) {
+	inner := &TableViewImpl{closedCh: make(chan struct{})}
+	tv := &typedTableView[string]{inner: inner}
+
+	_ = tv.Watch()
+	if len(inner.listeners) != 1 {
+		t.Fatalf("expected Watch() to register exactly one listener, got %d", len(inner.listeners))
+	}
+	listener := inner.listeners[0]
+
+	// Flood the listener with far more updates than the channel's buffer can hold, without
+	// draining it, simulating a consumer that can't keep up.
+	const updates = 100
+	for i := 0; i < updates; i++ {
+		if err := listener("key", "value"); err != nil {
+			t.Fatalf("listener() error = %v", err)
+		}
+	}
+
+	if dropped := tv.Dropped(); dropped == 0 {
+		t.Fatalf("expected Dropped() to report lost events, got 0")
+	}
+}